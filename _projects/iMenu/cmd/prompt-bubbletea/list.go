@@ -0,0 +1,203 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// headerSentinel marks a positional option as a non-selectable section
+// header, e.g. "---Branches---".
+const headerSentinel = "---"
+
+// listItem is the list.Item implementation backing pagedListModel. Headers
+// carry no description and are skipped over by movement and selection.
+type listItem struct {
+	title       string
+	description string
+	header      bool
+}
+
+func (i listItem) Title() string       { return i.title }
+func (i listItem) Description() string { return i.description }
+func (i listItem) FilterValue() string { return i.title }
+
+func isHeaderSentinel(s string) (string, bool) {
+	if strings.HasPrefix(s, headerSentinel) && strings.HasSuffix(s, headerSentinel) && len(s) > 2*len(headerSentinel) {
+		return strings.TrimSuffix(strings.TrimPrefix(s, headerSentinel), headerSentinel), true
+	}
+	return "", false
+}
+
+// buildListItems turns raw positional options into listItems, recognising
+// "---HEADER---" sentinels and taking each option's description from the
+// descriptions-file map. An option with no entry there is split on its first
+// ":" for an inline "option:description" form (the same convention gum
+// choose uses); there is no escape for a literal ":" in an option.
+func buildListItems(options []string, descriptions map[string]string) []listItem {
+	items := make([]listItem, 0, len(options))
+	for _, opt := range options {
+		if title, ok := isHeaderSentinel(opt); ok {
+			items = append(items, listItem{title: title, header: true})
+			continue
+		}
+
+		title := opt
+		description := descriptions[opt]
+		if idx := strings.Index(opt, ":"); idx > 0 && description == "" {
+			title = opt[:idx]
+			description = opt[idx+1:]
+		}
+
+		items = append(items, listItem{title: title, description: description})
+	}
+	return items
+}
+
+func loadDescriptionsFile(path string) (map[string]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("opening descriptions file: %w", err)
+	}
+	defer f.Close()
+
+	descriptions := map[string]string{}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		idx := strings.Index(line, ":")
+		if idx < 0 {
+			continue
+		}
+		descriptions[strings.TrimSpace(line[:idx])] = strings.TrimSpace(line[idx+1:])
+	}
+	return descriptions, scanner.Err()
+}
+
+// pagedDelegate renders listItems one per line, styling the cursor row and
+// section headers distinctly, and skipping headers on arrow movement.
+type pagedDelegate struct {
+	theme Theme
+}
+
+func (d pagedDelegate) Height() int  { return 1 }
+func (d pagedDelegate) Spacing() int { return 0 }
+
+func (d pagedDelegate) Update(msg tea.Msg, m *list.Model) tea.Cmd {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return nil
+	}
+
+	item, ok := m.SelectedItem().(listItem)
+	if !ok || !item.header {
+		return nil
+	}
+
+	switch keyMsg.String() {
+	case "up", "k":
+		m.CursorUp()
+	case "down", "j":
+		m.CursorDown()
+	}
+	return nil
+}
+
+func (d pagedDelegate) Render(w io.Writer, m list.Model, index int, li list.Item) {
+	it, ok := li.(listItem)
+	if !ok {
+		return
+	}
+
+	if it.header {
+		fmt.Fprint(w, d.theme.helpStyle().Bold(true).Render("── "+it.title+" ──"))
+		return
+	}
+
+	line := it.title
+	if it.description != "" {
+		line += "  " + d.theme.unselectedStyle().Render(it.description)
+	}
+
+	if index == m.Index() {
+		fmt.Fprint(w, d.theme.cursorStyle().Render("> "+line))
+	} else {
+		fmt.Fprint(w, "  "+line)
+	}
+}
+
+// pagedListModel is the bubbles/list-backed replacement for selectModel,
+// used once the option count (or an explicit --height) calls for paging,
+// filtering, and section headers that selectModel can't provide.
+type pagedListModel struct {
+	list      list.Model
+	done      bool
+	cancelled bool
+}
+
+func initialPagedListModel(message string, items []listItem, height int, theme Theme) pagedListModel {
+	listItems := make([]list.Item, len(items))
+	for i, it := range items {
+		listItems[i] = it
+	}
+
+	l := list.New(listItems, pagedDelegate{theme: theme}, 80, height)
+	l.Title = message
+	l.SetShowStatusBar(false)
+	l.SetFilteringEnabled(true)
+
+	return pagedListModel{list: l}
+}
+
+func (m pagedListModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m pagedListModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		if m.list.FilterState() != list.Filtering {
+			switch msg.String() {
+			case "ctrl+c", "esc":
+				m.cancelled = true
+				m.done = true
+				return m, tea.Quit
+			case "enter":
+				if it, ok := m.list.SelectedItem().(listItem); ok && !it.header {
+					m.done = true
+					return m, tea.Quit
+				}
+				return m, nil
+			}
+		}
+	case tea.WindowSizeMsg:
+		m.list.SetSize(msg.Width, msg.Height)
+	}
+
+	var cmd tea.Cmd
+	m.list, cmd = m.list.Update(msg)
+	return m, cmd
+}
+
+func (m pagedListModel) View() string {
+	if m.done {
+		return ""
+	}
+	return m.list.View()
+}
+
+func (m pagedListModel) selectedTitle() (string, bool) {
+	it, ok := m.list.SelectedItem().(listItem)
+	if !ok || it.header {
+		return "", false
+	}
+	return it.title, true
+}
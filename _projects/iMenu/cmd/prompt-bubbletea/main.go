@@ -1,10 +1,13 @@
 package main
 
 import (
+	"bufio"
 	"fmt"
 	"os"
+	"strconv"
 	"strings"
 
+	"github.com/charmbracelet/bubbles/textarea"
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
@@ -14,6 +17,9 @@ import (
 type inputModel struct {
 	textInput textinput.Model
 	message   string
+	validate  func(string) string
+	errMsg    string
+	theme     Theme
 	done      bool
 }
 
@@ -28,10 +34,33 @@ func initialInputModel(message, defaultValue string) inputModel {
 	return inputModel{
 		textInput: ti,
 		message:   message,
+		validate:  func(string) string { return "" },
+		theme:     builtinThemes["charm"],
 		done:      false,
 	}
 }
 
+func initialPasswordModel(message string, minLength int, validate func(string) string) inputModel {
+	ti := textinput.New()
+	ti.Focus()
+	ti.CharLimit = 0
+	ti.Width = 50
+	ti.EchoMode = textinput.EchoPassword
+	ti.EchoCharacter = '*'
+
+	return inputModel{
+		textInput: ti,
+		message:   message,
+		validate: func(s string) string {
+			if minLength > 0 && len(s) < minLength {
+				return fmt.Sprintf("must be at least %d characters", minLength)
+			}
+			return validate(s)
+		},
+		theme: builtinThemes["charm"],
+	}
+}
+
 func (m inputModel) Init() tea.Cmd {
 	return textinput.Blink
 }
@@ -43,6 +72,10 @@ func (m inputModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case "ctrl+c", "esc":
 			return m, tea.Quit
 		case "enter":
+			if errMsg := m.validate(m.textInput.Value()); errMsg != "" {
+				m.errMsg = errMsg
+				return m, nil
+			}
 			m.done = true
 			return m, tea.Quit
 		}
@@ -57,10 +90,86 @@ func (m inputModel) View() string {
 	if m.done {
 		return ""
 	}
-	return fmt.Sprintf("%s\n\n%s\n\n(Enter to confirm, Esc to cancel)",
-		m.message,
+
+	errLine := ""
+	if m.errMsg != "" {
+		errLine = "\n" + m.theme.errorStyle().Render(m.errMsg)
+	}
+
+	content := fmt.Sprintf("%s\n\n%s%s\n\n%s",
+		m.theme.titleStyle().Render(m.message),
 		m.textInput.View(),
+		errLine,
+		m.theme.descriptionStyle().Render("(Enter to confirm, Esc to cancel)"),
+	)
+	return m.theme.boxStyle().Render(content)
+}
+
+// Multiline model
+type multilineModel struct {
+	textarea textarea.Model
+	message  string
+	validate func(string) string
+	errMsg   string
+	theme    Theme
+	done     bool
+}
+
+func initialMultilineModel(message, defaultValue string) multilineModel {
+	ta := textarea.New()
+	ta.SetValue(defaultValue)
+	ta.Focus()
+
+	return multilineModel{
+		textarea: ta,
+		message:  message,
+		validate: func(string) string { return "" },
+		theme:    builtinThemes["charm"],
+	}
+}
+
+func (m multilineModel) Init() tea.Cmd {
+	return textarea.Blink
+}
+
+func (m multilineModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "ctrl+c", "esc":
+			return m, tea.Quit
+		case "ctrl+d":
+			if errMsg := m.validate(m.textarea.Value()); errMsg != "" {
+				m.errMsg = errMsg
+				return m, nil
+			}
+			m.done = true
+			return m, tea.Quit
+		}
+	}
+
+	var cmd tea.Cmd
+	m.textarea, cmd = m.textarea.Update(msg)
+	return m, cmd
+}
+
+func (m multilineModel) View() string {
+	if m.done {
+		return ""
+	}
+
+	errLine := ""
+	if m.errMsg != "" {
+		errLine = "\n" + m.theme.errorStyle().Render(m.errMsg)
+	}
+
+	content := fmt.Sprintf("%s\n\n%s%s\n\n%s",
+		m.theme.titleStyle().Render(m.message),
+		m.textarea.View(),
+		errLine,
+		m.theme.descriptionStyle().Render("(Ctrl+D to submit, Esc to cancel)"),
 	)
+	return m.theme.boxStyle().Render(content)
 }
 
 // Select model
@@ -69,6 +178,7 @@ type selectModel struct {
 	choices  []string
 	message  string
 	selected string
+	theme    Theme
 	done     bool
 }
 
@@ -77,6 +187,7 @@ func initialSelectModel(message string, choices []string) selectModel {
 		cursor:  0,
 		choices: choices,
 		message: message,
+		theme:   builtinThemes["charm"],
 		done:    false,
 	}
 }
@@ -114,28 +225,29 @@ func (m selectModel) View() string {
 	}
 
 	var s strings.Builder
-	s.WriteString(m.message)
+	s.WriteString(m.theme.titleStyle().Render(m.message))
 	s.WriteString("\n\n")
 
 	for i, choice := range m.choices {
 		cursor := " "
 		if m.cursor == i {
 			cursor = ">"
-			style := lipgloss.NewStyle().Foreground(lipgloss.Color("205"))
-			s.WriteString(fmt.Sprintf("%s %s\n", cursor, style.Render(choice)))
+			s.WriteString(fmt.Sprintf("%s %s\n", cursor, m.theme.selectedStyle().Render(choice)))
 		} else {
 			s.WriteString(fmt.Sprintf("%s %s\n", cursor, choice))
 		}
 	}
 
-	s.WriteString("\n(↑↓ to navigate, Enter to select, Esc to cancel)")
-	return s.String()
+	s.WriteString("\n")
+	s.WriteString(m.theme.descriptionStyle().Render("(↑↓ to navigate, Enter to select, Esc to cancel)"))
+	return m.theme.boxStyle().Render(s.String())
 }
 
 // Confirm model
 type confirmModel struct {
 	message string
 	yes     bool
+	theme   Theme
 	done    bool
 }
 
@@ -143,6 +255,7 @@ func initialConfirmModel(message string) confirmModel {
 	return confirmModel{
 		message: message,
 		yes:     false,
+		theme:   builtinThemes["charm"],
 		done:    false,
 	}
 }
@@ -177,28 +290,282 @@ func (m confirmModel) View() string {
 		return ""
 	}
 
-	yesStyle := lipgloss.NewStyle().Padding(0, 1)
-	noStyle := lipgloss.NewStyle().Padding(0, 1)
+	yesStyle := lipgloss.NewStyle()
+	noStyle := lipgloss.NewStyle()
 
 	if m.yes {
-		yesStyle = yesStyle.Background(lipgloss.Color("205")).Foreground(lipgloss.Color("230"))
-		noStyle = noStyle.Foreground(lipgloss.Color("240"))
+		yesStyle = yesStyle.Background(lipgloss.Color(m.theme.Selected)).Foreground(lipgloss.Color("230"))
+		noStyle = noStyle.Foreground(lipgloss.Color(m.theme.Unselected))
 	} else {
-		yesStyle = yesStyle.Foreground(lipgloss.Color("240"))
-		noStyle = noStyle.Background(lipgloss.Color("205")).Foreground(lipgloss.Color("230"))
+		yesStyle = yesStyle.Foreground(lipgloss.Color(m.theme.Unselected))
+		noStyle = noStyle.Background(lipgloss.Color(m.theme.Selected)).Foreground(lipgloss.Color("230"))
 	}
 
-	return fmt.Sprintf("%s\n\n[%s] [%s]\n\n(Y/N, ←→ to switch, Enter to confirm, Esc to cancel)",
-		m.message,
+	content := fmt.Sprintf("%s\n\n[%s] [%s]\n\n%s",
+		m.theme.titleStyle().Render(m.message),
 		yesStyle.Render("Yes"),
 		noStyle.Render("No"),
+		m.theme.descriptionStyle().Render("(Y/N, ←→ to switch, Enter to confirm, Esc to cancel)"),
 	)
+	return m.theme.boxStyle().Render(content)
+}
+
+// fuzzyMatch scores target against query using Sahil Malhotra's approach: a
+// candidate matches only if every query rune appears in target in order, and
+// the score rewards consecutive matches and matches at word boundaries while
+// penalizing gaps between matched runes. It returns the matched rune indices
+// (for highlighting) alongside the score.
+func fuzzyMatch(query, target string) (score int, positions []int, ok bool) {
+	if query == "" {
+		return 0, nil, true
+	}
+
+	q := []rune(strings.ToLower(query))
+	t := []rune(strings.ToLower(target))
+
+	qi := 0
+	lastMatch := -1
+	for ti := 0; ti < len(t) && qi < len(q); ti++ {
+		if t[ti] != q[qi] {
+			continue
+		}
+
+		bonus := 1
+		if ti == 0 || t[ti-1] == ' ' || t[ti-1] == '-' || t[ti-1] == '_' {
+			bonus += 4
+		}
+		if lastMatch >= 0 {
+			gap := ti - lastMatch - 1
+			if gap == 0 {
+				bonus += 3
+			} else {
+				bonus -= gap
+			}
+		}
+
+		score += bonus
+		positions = append(positions, ti)
+		lastMatch = ti
+		qi++
+	}
+
+	if qi < len(q) {
+		return 0, nil, false
+	}
+	return score, positions, true
+}
+
+type filterMatch struct {
+	text      string
+	index     int
+	score     int
+	positions []int
+}
+
+// filterModel renders a text input above a narrowing list of choices,
+// re-scoring and re-sorting on every keystroke via fuzzyMatch.
+type filterModel struct {
+	input     textinput.Model
+	message   string
+	all       []string
+	matches   []filterMatch
+	cursor    int
+	multi     bool
+	selected  map[int]bool
+	limit     int
+	theme     Theme
+	done      bool
+	cancelled bool
+}
+
+func initialFilterModel(message string, choices []string, multi bool, limit int, placeholder string) filterModel {
+	ti := textinput.New()
+	ti.Placeholder = placeholder
+	ti.Focus()
+	ti.CharLimit = 0
+	ti.Width = 50
+
+	m := filterModel{
+		input:    ti,
+		message:  message,
+		all:      choices,
+		multi:    multi,
+		selected: map[int]bool{},
+		limit:    limit,
+		theme:    builtinThemes["charm"],
+	}
+	m.recompute()
+	return m
+}
+
+func (m *filterModel) recompute() {
+	query := m.input.Value()
+	matches := make([]filterMatch, 0, len(m.all))
+	for i, choice := range m.all {
+		score, positions, ok := fuzzyMatch(query, choice)
+		if !ok {
+			continue
+		}
+		matches = append(matches, filterMatch{text: choice, index: i, score: score, positions: positions})
+	}
+
+	// Stable sort by descending score, ties broken by original index.
+	for i := 1; i < len(matches); i++ {
+		for j := i; j > 0 && matches[j].score > matches[j-1].score; j-- {
+			matches[j], matches[j-1] = matches[j-1], matches[j]
+		}
+	}
+
+	if m.limit > 0 && len(matches) > m.limit {
+		matches = matches[:m.limit]
+	}
+
+	m.matches = matches
+	if m.cursor >= len(m.matches) {
+		m.cursor = len(m.matches) - 1
+	}
+	if m.cursor < 0 {
+		m.cursor = 0
+	}
+}
+
+func (m filterModel) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+func (m filterModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "ctrl+c", "esc":
+			m.cancelled = true
+			m.done = true
+			return m, tea.Quit
+		case "up", "ctrl+k":
+			if m.cursor > 0 {
+				m.cursor--
+			}
+			return m, nil
+		case "down", "ctrl+j":
+			if m.cursor < len(m.matches)-1 {
+				m.cursor++
+			}
+			return m, nil
+		case "enter":
+			m.done = true
+			return m, tea.Quit
+		case " ":
+			if m.multi && len(m.matches) > 0 {
+				idx := m.matches[m.cursor].index
+				if m.selected[idx] {
+					delete(m.selected, idx)
+				} else {
+					m.selected[idx] = true
+				}
+				return m, nil
+			}
+		}
+	}
+
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	m.recompute()
+	return m, cmd
+}
+
+func (m filterModel) View() string {
+	if m.done {
+		return ""
+	}
+
+	matchStyle := m.theme.selectedStyle().Bold(true)
+	cursorStyle := m.theme.cursorStyle()
+	selectedStyle := m.theme.selectedStyle()
+
+	var s strings.Builder
+	s.WriteString(m.theme.titleStyle().Render(m.message))
+	s.WriteString("\n\n")
+	s.WriteString(m.input.View())
+	s.WriteString("\n\n")
+
+	for i, match := range m.matches {
+		marker := "  "
+		if m.multi {
+			if m.selected[match.index] {
+				marker = selectedStyle.Render("[x]")
+			} else {
+				marker = "[ ]"
+			}
+		}
+
+		cursor := " "
+		if i == m.cursor {
+			cursor = cursorStyle.Render(">")
+		}
+
+		s.WriteString(fmt.Sprintf("%s %s %s\n", cursor, marker, highlightMatch(match, matchStyle)))
+	}
+
+	help := "(type to filter, ↑↓ to navigate, Enter to confirm, Esc to cancel)"
+	if m.multi {
+		help = "(type to filter, ↑↓ to navigate, Space to toggle, Enter when done, Esc to cancel)"
+	}
+	s.WriteString("\n")
+	s.WriteString(m.theme.descriptionStyle().Render(help))
+	return m.theme.boxStyle().Render(s.String())
+}
+
+func highlightMatch(match filterMatch, style lipgloss.Style) string {
+	positions := map[int]bool{}
+	for _, p := range match.positions {
+		positions[p] = true
+	}
+
+	var s strings.Builder
+	for i, r := range []rune(match.text) {
+		if positions[i] {
+			s.WriteString(style.Render(string(r)))
+		} else {
+			s.WriteRune(r)
+		}
+	}
+	return s.String()
+}
+
+func readOptionsFromStdin() []string {
+	var options []string
+	scanner := bufio.NewScanner(os.Stdin)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if line != "" {
+			options = append(options, line)
+		}
+	}
+	return options
+}
+
+func writeFilterResult(resultFile, data string) {
+	if resultFile != "" {
+		os.WriteFile(resultFile, []byte(data+"\n"), 0644)
+	} else {
+		fmt.Println(data)
+	}
+}
+
+func writeFilterResultLines(resultFile string, lines []string) {
+	if resultFile != "" {
+		os.WriteFile(resultFile, []byte(strings.Join(lines, "\n")+"\n"), 0644)
+	} else {
+		for _, line := range lines {
+			fmt.Println(line)
+		}
+	}
 }
 
 func main() {
 	if len(os.Args) < 2 {
 		fmt.Fprintf(os.Stderr, "Usage: %s <type> [options]\n", os.Args[0])
-		fmt.Fprintf(os.Stderr, "Types: input, select, confirm\n")
+		fmt.Fprintf(os.Stderr, "Types: input, select, confirm, password, multiline, editor, filter, fuzzy-select, fuzzy-multiselect, serve, dump-theme\n")
 		os.Exit(1)
 	}
 
@@ -206,17 +573,84 @@ func main() {
 	var p *tea.Program
 	var result string
 
+	// Extract flags shared across prompt types before positional parsing.
+	extraArgs := os.Args[2:]
+	validateSpec := ""
+	minLength := 0
+	themeName := ""
+	themeFile := ""
+	for i := 0; i < len(extraArgs); i++ {
+		switch {
+		case extraArgs[i] == "--validate" && i+1 < len(extraArgs):
+			validateSpec = extraArgs[i+1]
+			extraArgs = append(extraArgs[:i], extraArgs[i+2:]...)
+			i--
+		case extraArgs[i] == "--min-length" && i+1 < len(extraArgs):
+			minLength, _ = strconv.Atoi(extraArgs[i+1])
+			extraArgs = append(extraArgs[:i], extraArgs[i+2:]...)
+			i--
+		case extraArgs[i] == "--theme" && i+1 < len(extraArgs):
+			themeName = extraArgs[i+1]
+			extraArgs = append(extraArgs[:i], extraArgs[i+2:]...)
+			i--
+		case extraArgs[i] == "--theme-file" && i+1 < len(extraArgs):
+			themeFile = extraArgs[i+1]
+			extraArgs = append(extraArgs[:i], extraArgs[i+2:]...)
+			i--
+		}
+	}
+	validate := parseValidateSpec(validateSpec)
+	theme, err := loadTheme(themeName, themeFile)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
 	switch promptType {
+	case "dump-theme":
+		dumped, err := dumpTheme(theme)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Print(dumped)
+		return
+
+	case "serve":
+		if err := runServe(); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		return
+
 	case "input":
 		message := "Enter value:"
 		defaultValue := ""
-		if len(os.Args) > 2 {
-			message = os.Args[2]
+		if len(extraArgs) > 0 {
+			message = extraArgs[0]
 		}
-		if len(os.Args) > 3 {
-			defaultValue = os.Args[3]
+		if len(extraArgs) > 1 {
+			defaultValue = extraArgs[1]
 		}
 		m := initialInputModel(message, defaultValue)
+		m.validate = validate
+		m.theme = theme
+		p = tea.NewProgram(m, tea.WithAltScreen())
+		finalModel, err := p.Run()
+		if err != nil {
+			os.Exit(1)
+		}
+		if im, ok := finalModel.(inputModel); ok && im.done {
+			result = im.textInput.Value()
+		}
+
+	case "password":
+		message := "Enter password:"
+		if len(extraArgs) > 0 {
+			message = extraArgs[0]
+		}
+		m := initialPasswordModel(message, minLength, validate)
+		m.theme = theme
 		p = tea.NewProgram(m, tea.WithAltScreen())
 		finalModel, err := p.Run()
 		if err != nil {
@@ -226,28 +660,139 @@ func main() {
 			result = im.textInput.Value()
 		}
 
+	case "multiline":
+		message := "Enter text:"
+		defaultValue := ""
+		if len(extraArgs) > 0 {
+			message = extraArgs[0]
+		}
+		if len(extraArgs) > 1 {
+			defaultValue = extraArgs[1]
+		}
+		m := initialMultilineModel(message, defaultValue)
+		m.validate = validate
+		m.theme = theme
+		p = tea.NewProgram(m, tea.WithAltScreen())
+		finalModel, err := p.Run()
+		if err != nil {
+			os.Exit(1)
+		}
+		if mm, ok := finalModel.(multilineModel); ok && mm.done {
+			result = mm.textarea.Value()
+		}
+
+	case "editor":
+		message := "Enter value:"
+		defaultValue := ""
+		if len(extraArgs) > 0 {
+			message = extraArgs[0]
+		}
+		if len(extraArgs) > 1 {
+			defaultValue = extraArgs[1]
+		}
+		fmt.Fprintf(os.Stderr, "%s (opening $EDITOR)\n", message)
+		edited, err := runEditor(defaultValue)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		if errMsg := validate(edited); errMsg != "" {
+			fmt.Fprintf(os.Stderr, "Error: %s\n", errMsg)
+			os.Exit(1)
+		}
+		result = edited
+
 	case "select":
-		if len(os.Args) < 3 {
+		args := extraArgs
+		descriptionsFile := ""
+		height := 0
+		for i := 0; i < len(args); i++ {
+			switch args[i] {
+			case "--descriptions-file":
+				if i+1 < len(args) {
+					descriptionsFile = args[i+1]
+					args = append(args[:i], args[i+2:]...)
+					i--
+				}
+			case "--height":
+				if i+1 < len(args) {
+					height, _ = strconv.Atoi(args[i+1])
+					args = append(args[:i], args[i+2:]...)
+					i--
+				}
+			}
+		}
+
+		if len(args) < 1 {
 			fmt.Fprintf(os.Stderr, "Usage: %s select <message> <option1> [option2] ...\n", os.Args[0])
 			os.Exit(1)
 		}
-		message := os.Args[2]
-		choices := os.Args[3:]
+		message := args[0]
+		choices := args[1:]
 		if len(choices) == 0 {
 			fmt.Fprintf(os.Stderr, "Error: At least one option required\n")
 			os.Exit(1)
 		}
-		m := initialSelectModel(message, choices)
+
+		descriptions := map[string]string{}
+		if descriptionsFile != "" {
+			var err error
+			descriptions, err = loadDescriptionsFile(descriptionsFile)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+		}
+
+		items := buildListItems(choices, descriptions)
+		selectableCount := 0
+		for _, it := range items {
+			if !it.header {
+				selectableCount++
+			}
+		}
+
+		const pagedThreshold = 20
+		if height == 0 && selectableCount < pagedThreshold {
+			simpleChoices := make([]string, 0, selectableCount)
+			for _, it := range items {
+				if !it.header {
+					simpleChoices = append(simpleChoices, it.title)
+				}
+			}
+			m := initialSelectModel(message, simpleChoices)
+			m.theme = theme
+			p = tea.NewProgram(m, tea.WithAltScreen())
+			finalModel, err := p.Run()
+			if err != nil {
+				os.Exit(1)
+			}
+			if sm, ok := finalModel.(selectModel); ok && sm.done {
+				result = sm.selected
+			} else {
+				os.Exit(1)
+			}
+			break
+		}
+
+		if height == 0 {
+			height = 14
+		}
+		m := initialPagedListModel(message, items, height, theme)
 		p = tea.NewProgram(m, tea.WithAltScreen())
 		finalModel, err := p.Run()
 		if err != nil {
 			os.Exit(1)
 		}
-		if sm, ok := finalModel.(selectModel); ok && sm.done {
-			result = sm.selected
-		} else {
+		lm, ok := finalModel.(pagedListModel)
+		if !ok || !lm.done || lm.cancelled {
 			os.Exit(1)
 		}
+		title, ok := lm.selectedTitle()
+		if !ok {
+			os.Exit(1)
+		}
+		result = title
 
 	case "confirm":
 		message := "Continue?"
@@ -255,6 +800,7 @@ func main() {
 			message = strings.Join(os.Args[2:], " ")
 		}
 		m := initialConfirmModel(message)
+		m.theme = theme
 		p = tea.NewProgram(m, tea.WithAltScreen())
 		finalModel, err := p.Run()
 		if err != nil {
@@ -272,6 +818,92 @@ func main() {
 			os.Exit(1)
 		}
 
+	case "filter", "fuzzy-select", "fuzzy-multiselect":
+		args := extraArgs
+		resultFile := ""
+		limit := 0
+		selectIfOne := false
+		placeholder := ""
+
+		for i := 0; i < len(args); i++ {
+			switch args[i] {
+			case "--result-file":
+				if i+1 < len(args) {
+					resultFile = args[i+1]
+					args = append(args[:i], args[i+2:]...)
+					i--
+				}
+			case "--limit":
+				if i+1 < len(args) {
+					limit, _ = strconv.Atoi(args[i+1])
+					args = append(args[:i], args[i+2:]...)
+					i--
+				}
+			case "--select-if-one":
+				selectIfOne = true
+				args = append(args[:i], args[i+1:]...)
+				i--
+			case "--placeholder":
+				if i+1 < len(args) {
+					placeholder = args[i+1]
+					args = append(args[:i], args[i+2:]...)
+					i--
+				}
+			}
+		}
+
+		message := "Filter:"
+		var choices []string
+		if len(args) > 0 {
+			message = args[0]
+			choices = args[1:]
+		}
+		if len(choices) == 0 {
+			choices = readOptionsFromStdin()
+		}
+		if len(choices) == 0 {
+			fmt.Fprintf(os.Stderr, "Error: At least one option required\n")
+			os.Exit(1)
+		}
+
+		multi := promptType == "fuzzy-multiselect"
+
+		if selectIfOne && len(choices) == 1 {
+			if multi {
+				writeFilterResultLines(resultFile, choices)
+			} else {
+				writeFilterResult(resultFile, choices[0])
+			}
+			return
+		}
+
+		m := initialFilterModel(message, choices, multi, limit, placeholder)
+		m.theme = theme
+		p = tea.NewProgram(m, tea.WithAltScreen())
+		finalModel, err := p.Run()
+		if err != nil {
+			os.Exit(1)
+		}
+		fm, ok := finalModel.(filterModel)
+		if !ok || !fm.done || fm.cancelled {
+			os.Exit(1)
+		}
+
+		if multi {
+			var selected []string
+			for i, choice := range fm.all {
+				if fm.selected[i] {
+					selected = append(selected, choice)
+				}
+			}
+			writeFilterResultLines(resultFile, selected)
+		} else if len(fm.matches) > 0 {
+			writeFilterResult(resultFile, fm.matches[fm.cursor].text)
+		} else {
+			os.Exit(1)
+		}
+		return
+
 	default:
 		fmt.Fprintf(os.Stderr, "Unknown prompt type: %s\n", promptType)
 		os.Exit(1)
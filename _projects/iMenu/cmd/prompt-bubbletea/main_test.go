@@ -0,0 +1,94 @@
+package main
+
+import "testing"
+
+func TestFuzzyMatch(t *testing.T) {
+	tests := []struct {
+		name      string
+		query     string
+		target    string
+		wantOK    bool
+		wantScore int
+	}{
+		{name: "empty query matches anything", query: "", target: "anything", wantOK: true, wantScore: 0},
+		{name: "exact match", query: "foo", target: "foo", wantOK: true},
+		{name: "case insensitive", query: "FOO", target: "foo", wantOK: true},
+		{name: "ordered subsequence matches", query: "fb", target: "foobar", wantOK: true},
+		{name: "out of order does not match", query: "ba", target: "ab", wantOK: false},
+		{name: "missing rune does not match", query: "xyz", target: "foobar", wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			score, positions, ok := fuzzyMatch(tt.query, tt.target)
+			if ok != tt.wantOK {
+				t.Fatalf("fuzzyMatch(%q, %q) ok = %v, want %v", tt.query, tt.target, ok, tt.wantOK)
+			}
+			if !ok {
+				return
+			}
+			if tt.query != "" && len(positions) != len([]rune(tt.query)) {
+				t.Fatalf("fuzzyMatch(%q, %q) positions = %v, want %d entries", tt.query, tt.target, positions, len([]rune(tt.query)))
+			}
+			if tt.name == "empty query matches anything" && score != tt.wantScore {
+				t.Fatalf("fuzzyMatch(%q, %q) score = %d, want %d", tt.query, tt.target, score, tt.wantScore)
+			}
+		})
+	}
+}
+
+func TestFuzzyMatchScoresWordBoundaryMatchesHigher(t *testing.T) {
+	// "fb" should score higher against "foo bar" (hits two word starts) than
+	// against "xfxbx" (no word-boundary or consecutive bonuses).
+	wordBoundaryScore, _, ok := fuzzyMatch("fb", "foo bar")
+	if !ok {
+		t.Fatal("expected match against \"foo bar\"")
+	}
+	noBonusScore, _, ok := fuzzyMatch("fb", "xfxbx")
+	if !ok {
+		t.Fatal("expected match against \"xfxbx\"")
+	}
+	if wordBoundaryScore <= noBonusScore {
+		t.Fatalf("expected word-boundary score %d to beat plain score %d", wordBoundaryScore, noBonusScore)
+	}
+}
+
+func TestFuzzyMatchScoresConsecutiveRunsHigher(t *testing.T) {
+	consecutive, _, ok := fuzzyMatch("ab", "ab")
+	if !ok {
+		t.Fatal("expected match against \"ab\"")
+	}
+	gapped, _, ok := fuzzyMatch("ab", "a_______b")
+	if !ok {
+		t.Fatal("expected match against \"a_______b\"")
+	}
+	if consecutive <= gapped {
+		t.Fatalf("expected consecutive match score %d to beat gapped score %d", consecutive, gapped)
+	}
+}
+
+func TestFilterModelRecomputeSortsByScoreThenOriginalIndex(t *testing.T) {
+	m := initialFilterModel("pick one", []string{"bar", "foobar", "foo"}, false, 0, "")
+	m.input.SetValue("foo")
+	m.recompute()
+
+	if len(m.matches) != 2 {
+		t.Fatalf("matches = %v, want 2 entries matching %q", m.matches, "foo")
+	}
+	// "foobar" and "foo" both start with "foo", so they tie on score; ties
+	// break by original index, so "foobar" (index 1) sorts before "foo"
+	// (index 2).
+	if m.matches[0].text != "foobar" || m.matches[1].text != "foo" {
+		t.Fatalf("matches = %v, want [foobar foo] in that order", m.matches)
+	}
+}
+
+func TestFilterModelRecomputeAppliesLimit(t *testing.T) {
+	m := initialFilterModel("pick one", []string{"a1", "a2", "a3"}, false, 2, "")
+	m.input.SetValue("a")
+	m.recompute()
+
+	if len(m.matches) != 2 {
+		t.Fatalf("matches = %v, want 2 entries after limiting to 2", m.matches)
+	}
+}
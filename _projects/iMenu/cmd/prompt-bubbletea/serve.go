@@ -0,0 +1,165 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// serveRequest is one line of the serve subcommand's stdin protocol.
+type serveRequest struct {
+	ID      string   `json:"id"`
+	Type    string   `json:"type"`
+	Message string   `json:"message"`
+	Options []string `json:"options"`
+	Default string   `json:"default"`
+	Cancel  bool     `json:"cancel"`
+}
+
+// serveResponse is one line of the serve subcommand's stdout protocol.
+type serveResponse struct {
+	ID    string      `json:"id"`
+	OK    bool        `json:"ok"`
+	Value interface{} `json:"value,omitempty"`
+	Error string      `json:"error,omitempty"`
+}
+
+// runServe keeps the process alive, reading newline-delimited JSON requests
+// from stdin and writing newline-delimited JSON responses to stdout, while
+// every prompt renders to stderr and reads keystrokes from the controlling
+// terminal. This lets a single long-running wrapper script drive an entire
+// interactive session over one pipe instead of paying a fresh process
+// startup per prompt.
+func runServe() error {
+	tty, err := os.Open("/dev/tty")
+	if err != nil {
+		return fmt.Errorf("opening controlling terminal: %w", err)
+	}
+	defer tty.Close()
+
+	enc := json.NewEncoder(os.Stdout)
+
+	var mu sync.Mutex
+	var activeID string
+	var activeProgram *tea.Program
+
+	// reqCh only ever carries requests the main loop must handle serially
+	// (one prompt at a time). Cancel requests are handled right here in the
+	// reader goroutine instead of being sent down reqCh, so a "cancel" line
+	// reaches activeProgram.Quit() immediately instead of queuing behind
+	// whatever blocking p.Run() the main loop is currently inside.
+	reqCh := make(chan serveRequest)
+	go func() {
+		defer close(reqCh)
+		scanner := bufio.NewScanner(os.Stdin)
+		scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+		for scanner.Scan() {
+			line := strings.TrimSpace(scanner.Text())
+			if line == "" {
+				continue
+			}
+			var req serveRequest
+			if err := json.Unmarshal([]byte(line), &req); err != nil {
+				enc.Encode(serveResponse{OK: false, Error: fmt.Sprintf("invalid request: %v", err)})
+				continue
+			}
+			if req.Cancel {
+				mu.Lock()
+				if activeProgram != nil && req.ID == activeID {
+					activeProgram.Quit()
+				}
+				mu.Unlock()
+				continue
+			}
+			reqCh <- req
+		}
+	}()
+
+	for req := range reqCh {
+		if req.Type == "notify" {
+			fmt.Fprintln(os.Stderr, req.Message)
+			enc.Encode(serveResponse{ID: req.ID, OK: true})
+			continue
+		}
+
+		value, err := runServePrompt(req, tty, &mu, &activeProgram, &activeID)
+		if err != nil {
+			enc.Encode(serveResponse{ID: req.ID, OK: false, Error: err.Error()})
+			continue
+		}
+		enc.Encode(serveResponse{ID: req.ID, OK: true, Value: value})
+	}
+
+	return nil
+}
+
+// runServePrompt builds and runs the tea.Program for a single serve request,
+// registering it as the cancellable "active" program for the duration.
+func runServePrompt(req serveRequest, tty *os.File, mu *sync.Mutex, activeProgram **tea.Program, activeID *string) (interface{}, error) {
+	var model tea.Model
+	switch req.Type {
+	case "input":
+		model = initialInputModel(req.Message, req.Default)
+	case "password":
+		model = initialPasswordModel(req.Message, 0, func(string) string { return "" })
+	case "confirm":
+		model = initialConfirmModel(req.Message)
+	case "select":
+		if len(req.Options) == 0 {
+			return nil, fmt.Errorf("select requires options")
+		}
+		model = initialSelectModel(req.Message, req.Options)
+	case "multiline":
+		model = initialMultilineModel(req.Message, req.Default)
+	default:
+		return nil, fmt.Errorf("unknown request type %q", req.Type)
+	}
+
+	p := tea.NewProgram(model, tea.WithInput(tty), tea.WithOutput(os.Stderr))
+
+	mu.Lock()
+	*activeProgram = p
+	*activeID = req.ID
+	mu.Unlock()
+
+	final, err := p.Run()
+
+	mu.Lock()
+	*activeProgram = nil
+	*activeID = ""
+	mu.Unlock()
+
+	if err != nil {
+		return nil, err
+	}
+
+	switch m := final.(type) {
+	case inputModel:
+		if !m.done {
+			return nil, fmt.Errorf("cancelled")
+		}
+		return m.textInput.Value(), nil
+	case confirmModel:
+		if !m.done {
+			return nil, fmt.Errorf("cancelled")
+		}
+		return m.yes, nil
+	case selectModel:
+		if !m.done {
+			return nil, fmt.Errorf("cancelled")
+		}
+		return m.selected, nil
+	case multilineModel:
+		if !m.done {
+			return nil, fmt.Errorf("cancelled")
+		}
+		return m.textarea.Value(), nil
+	default:
+		return nil, fmt.Errorf("cancelled")
+	}
+}
@@ -0,0 +1,134 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/BurntSushi/toml"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Theme holds every color/spacing knob the bubbletea models render with, so
+// a single struct can be swapped to restyle every prompt type at once.
+type Theme struct {
+	Name          string `toml:"name"`
+	Title         string `toml:"title"`
+	Description   string `toml:"description"`
+	Cursor        string `toml:"cursor"`
+	Selected      string `toml:"selected"`
+	Unselected    string `toml:"unselected"`
+	Error         string `toml:"error"`
+	Help          string `toml:"help"`
+	FocusedBorder string `toml:"focused_border"`
+	PaddingV      int    `toml:"padding_v"`
+	PaddingH      int    `toml:"padding_h"`
+}
+
+var builtinThemes = map[string]Theme{
+	"charm": {
+		Name: "charm", Title: "212", Description: "240", Cursor: "205",
+		Selected: "205", Unselected: "252", Error: "196", Help: "241",
+		FocusedBorder: "212", PaddingV: 0, PaddingH: 1,
+	},
+	"dracula": {
+		Name: "dracula", Title: "141", Description: "103", Cursor: "212",
+		Selected: "212", Unselected: "231", Error: "203", Help: "61",
+		FocusedBorder: "141", PaddingV: 0, PaddingH: 1,
+	},
+	"catppuccin": {
+		Name: "catppuccin", Title: "183", Description: "146", Cursor: "211",
+		Selected: "211", Unselected: "189", Error: "210", Help: "139",
+		FocusedBorder: "183", PaddingV: 0, PaddingH: 1,
+	},
+	"base16": {
+		Name: "base16", Title: "4", Description: "8", Cursor: "2",
+		Selected: "2", Unselected: "7", Error: "1", Help: "8",
+		FocusedBorder: "4", PaddingV: 0, PaddingH: 1,
+	},
+}
+
+func defaultThemeConfigPath() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".config", "devboards", "theme.toml")
+}
+
+// loadTheme resolves a theme from, in priority order: an explicit
+// --theme-file, a named built-in via --theme, or ~/.config/devboards/theme.toml,
+// falling back to the "charm" built-in.
+func loadTheme(name, file string) (Theme, error) {
+	if file != "" {
+		return loadThemeFile(file)
+	}
+	if name != "" {
+		t, ok := builtinThemes[name]
+		if !ok {
+			return Theme{}, fmt.Errorf("unknown theme %q", name)
+		}
+		return t, nil
+	}
+	if path := defaultThemeConfigPath(); path != "" {
+		if _, err := os.Stat(path); err == nil {
+			return loadThemeFile(path)
+		}
+	}
+	return builtinThemes["charm"], nil
+}
+
+func loadThemeFile(path string) (Theme, error) {
+	t := builtinThemes["charm"]
+	if _, err := toml.DecodeFile(path, &t); err != nil {
+		return Theme{}, fmt.Errorf("loading theme file %s: %w", path, err)
+	}
+	return t, nil
+}
+
+func dumpTheme(t Theme) (string, error) {
+	var buf bytes.Buffer
+	if err := toml.NewEncoder(&buf).Encode(t); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+func (t Theme) titleStyle() lipgloss.Style {
+	return lipgloss.NewStyle().Foreground(lipgloss.Color(t.Title))
+}
+
+func (t Theme) descriptionStyle() lipgloss.Style {
+	return lipgloss.NewStyle().Foreground(lipgloss.Color(t.Description))
+}
+
+// boxStyle is the rounded, padded, theme-bordered frame every prompt model
+// renders its content inside, tying FocusedBorder/PaddingV/PaddingH to an
+// actual render path instead of sitting unused in the struct.
+func (t Theme) boxStyle() lipgloss.Style {
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color(t.FocusedBorder)).
+		Padding(t.PaddingV, t.PaddingH)
+}
+
+func (t Theme) cursorStyle() lipgloss.Style {
+	return lipgloss.NewStyle().Foreground(lipgloss.Color(t.Cursor))
+}
+
+func (t Theme) selectedStyle() lipgloss.Style {
+	return lipgloss.NewStyle().Foreground(lipgloss.Color(t.Selected))
+}
+
+func (t Theme) unselectedStyle() lipgloss.Style {
+	return lipgloss.NewStyle().Foreground(lipgloss.Color(t.Unselected))
+}
+
+func (t Theme) errorStyle() lipgloss.Style {
+	return lipgloss.NewStyle().Foreground(lipgloss.Color(t.Error))
+}
+
+func (t Theme) helpStyle() lipgloss.Style {
+	return lipgloss.NewStyle().Foreground(lipgloss.Color(t.Help))
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"fmt"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// parseValidateSpec turns a --validate flag value (one of "email", "url",
+// "int", "float", "nonempty", or "regex:PATTERN") into a validator func that
+// returns an error message to show under the field, or "" when valid.
+func parseValidateSpec(spec string) func(string) string {
+	if spec == "" {
+		return func(string) string { return "" }
+	}
+
+	if rest, ok := strings.CutPrefix(spec, "regex:"); ok {
+		re, err := regexp.Compile(rest)
+		if err != nil {
+			return func(string) string { return fmt.Sprintf("invalid regex %q", rest) }
+		}
+		return func(s string) string {
+			if !re.MatchString(s) {
+				return fmt.Sprintf("must match pattern %s", rest)
+			}
+			return ""
+		}
+	}
+
+	switch spec {
+	case "email":
+		return func(s string) string {
+			if _, err := mail.ParseAddress(s); err != nil {
+				return "must be a valid email address"
+			}
+			return ""
+		}
+	case "url":
+		return func(s string) string {
+			u, err := url.ParseRequestURI(s)
+			if err != nil || u.Scheme == "" || u.Host == "" {
+				return "must be a valid URL"
+			}
+			return ""
+		}
+	case "int":
+		return func(s string) string {
+			if _, err := strconv.Atoi(s); err != nil {
+				return "must be an integer"
+			}
+			return ""
+		}
+	case "float":
+		return func(s string) string {
+			if _, err := strconv.ParseFloat(s, 64); err != nil {
+				return "must be a number"
+			}
+			return ""
+		}
+	default: // "nonempty" and anything unrecognised
+		return func(s string) string {
+			if strings.TrimSpace(s) == "" {
+				return "must not be empty"
+			}
+			return ""
+		}
+	}
+}
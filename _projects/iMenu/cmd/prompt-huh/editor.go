@@ -0,0 +1,53 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+)
+
+// runEditor spawns $EDITOR (falling back to $VISUAL, then a platform
+// default) on a tempfile pre-populated with defaultValue, and returns the
+// saved contents as the answer.
+func runEditor(defaultValue string) (string, error) {
+	tmp, err := os.CreateTemp("", "devboards-editor-*.txt")
+	if err != nil {
+		return "", fmt.Errorf("creating tempfile: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.WriteString(defaultValue); err != nil {
+		tmp.Close()
+		return "", fmt.Errorf("writing tempfile: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return "", fmt.Errorf("closing tempfile: %w", err)
+	}
+
+	editor := os.Getenv("EDITOR")
+	if editor == "" {
+		editor = os.Getenv("VISUAL")
+	}
+	if editor == "" {
+		if runtime.GOOS == "windows" {
+			editor = "notepad"
+		} else {
+			editor = "vi"
+		}
+	}
+
+	cmd := exec.Command(editor, tmp.Name())
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("running editor %s: %w", editor, err)
+	}
+
+	contents, err := os.ReadFile(tmp.Name())
+	if err != nil {
+		return "", fmt.Errorf("reading tempfile: %w", err)
+	}
+	return string(contents), nil
+}
@@ -0,0 +1,338 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/huh"
+	"gopkg.in/yaml.v3"
+)
+
+func regexpMatch(pattern, value string) (bool, error) {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return false, fmt.Errorf("invalid regex %q: %w", pattern, err)
+	}
+	return re.MatchString(value), nil
+}
+
+// formField describes one prompt in a form spec. Only the fields relevant to
+// its Type are read; the rest are ignored.
+type formField struct {
+	Type        string   `json:"type" yaml:"type"`
+	Key         string   `json:"key" yaml:"key"`
+	Title       string   `json:"title" yaml:"title"`
+	Description string   `json:"description" yaml:"description"`
+	Default     string   `json:"default" yaml:"default"`
+	Options     []string `json:"options" yaml:"options"`
+	Required    bool     `json:"required" yaml:"required"`
+	Regex       string   `json:"regex" yaml:"regex"`
+	Min         *int     `json:"min" yaml:"min"`
+	Max         *int     `json:"max" yaml:"max"`
+	When        string   `json:"when" yaml:"when"`
+	Page        int      `json:"page" yaml:"page"`
+}
+
+// formSpec is the top-level shape of a form JSON/YAML document: an ordered
+// list of fields, optionally grouped into pages via formField.Page.
+type formSpec struct {
+	Fields []formField `json:"fields" yaml:"fields"`
+}
+
+func loadFormSpec(path string) (*formSpec, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading spec: %w", err)
+	}
+
+	var spec formSpec
+	if strings.HasSuffix(path, ".yaml") || strings.HasSuffix(path, ".yml") {
+		if err := yaml.Unmarshal(data, &spec); err != nil {
+			return nil, fmt.Errorf("parsing yaml spec: %w", err)
+		}
+	} else {
+		if err := json.Unmarshal(data, &spec); err != nil {
+			return nil, fmt.Errorf("parsing json spec: %w", err)
+		}
+	}
+	return &spec, nil
+}
+
+// evalWhen evaluates a small boolean expression of the form
+// `key==value`, `key!=value`, combined with `&&` and `||`, against a
+// snapshot of answers. Dotted keys are just map keys that contain a dot;
+// no nested traversal is performed.
+func evalWhen(expr string, answers map[string]string) bool {
+	expr = strings.TrimSpace(expr)
+	if expr == "" {
+		return true
+	}
+
+	if parts := strings.SplitN(expr, "||", 2); len(parts) == 2 {
+		return evalWhen(parts[0], answers) || evalWhen(parts[1], answers)
+	}
+	if parts := strings.SplitN(expr, "&&", 2); len(parts) == 2 {
+		return evalWhen(parts[0], answers) && evalWhen(parts[1], answers)
+	}
+
+	if parts := strings.SplitN(expr, "!=", 2); len(parts) == 2 {
+		key := strings.TrimSpace(parts[0])
+		want := strings.Trim(strings.TrimSpace(parts[1]), `"'`)
+		return answers[key] != want
+	}
+	if parts := strings.SplitN(expr, "==", 2); len(parts) == 2 {
+		key := strings.TrimSpace(parts[0])
+		want := strings.Trim(strings.TrimSpace(parts[1]), `"'`)
+		return answers[key] == want
+	}
+
+	return true
+}
+
+// buildValidator turns a field's validation settings into a huh validator
+// func, re-prompting on failure the same way the --validate flag does for
+// individual prompt types.
+func buildValidator(f formField) func(string) error {
+	return func(s string) error {
+		if f.Required && strings.TrimSpace(s) == "" {
+			return fmt.Errorf("%s is required", f.Title)
+		}
+		if f.Regex != "" {
+			ok, err := regexpMatch(f.Regex, s)
+			if err != nil {
+				return err
+			}
+			if !ok {
+				return fmt.Errorf("%s does not match pattern %s", f.Title, f.Regex)
+			}
+		}
+		if f.Min != nil || f.Max != nil {
+			n, err := strconv.Atoi(s)
+			if err != nil {
+				return fmt.Errorf("%s must be a number", f.Title)
+			}
+			if f.Min != nil && n < *f.Min {
+				return fmt.Errorf("%s must be >= %d", f.Title, *f.Min)
+			}
+			if f.Max != nil && n > *f.Max {
+				return fmt.Errorf("%s must be <= %d", f.Title, *f.Max)
+			}
+		}
+		return nil
+	}
+}
+
+// runForm reads a form spec from specPath, runs it as a single huh.Form with
+// one group per page, and returns the collected key->value answers in field
+// order. Answers are strings except for multiselect fields, which carry
+// their selections through as []string so they round-trip as a JSON array
+// instead of a comma-joined string that can't distinguish a selected option
+// containing a comma from two separate options. Fields whose When predicate
+// evaluates false are hidden at render time, evaluated against whatever the
+// user has answered so far in the same running form; huh only supports
+// hiding whole groups (Group.WithHideFunc), so a conditional field is
+// wrapped in a single-field group of its own rather than joining its page's
+// shared group.
+func runForm(specPath string, huhTheme *huh.Theme) (map[string]interface{}, []string, error) {
+	spec, err := loadFormSpec(specPath)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	answers := map[string]interface{}{}
+	order := []string{}
+
+	pages := map[int][]formField{}
+	pageNums := []int{}
+	for _, f := range spec.Fields {
+		if _, ok := pages[f.Page]; !ok {
+			pageNums = append(pageNums, f.Page)
+		}
+		pages[f.Page] = append(pages[f.Page], f)
+	}
+
+	var groups []*huh.Group
+	fieldValues := map[string]*string{}
+	boolValues := map[string]*bool{}
+	multiValues := map[string]*[]string{}
+
+	// snapshot builds the live answers map evalWhen needs, read fresh on
+	// every hide-func call so later fields can branch on earlier ones.
+	snapshot := func() map[string]string {
+		live := map[string]string{}
+		for key, val := range fieldValues {
+			if val != nil {
+				live[key] = *val
+			}
+		}
+		for key, val := range boolValues {
+			if *val {
+				live[key] = "true"
+			} else {
+				live[key] = "false"
+			}
+		}
+		for key, val := range multiValues {
+			live[key] = strings.Join(*val, ",")
+		}
+		return live
+	}
+
+	for _, pn := range pageNums {
+		var huhFields []huh.Field
+		flush := func() {
+			if len(huhFields) > 0 {
+				groups = append(groups, huh.NewGroup(huhFields...))
+				huhFields = nil
+			}
+		}
+
+		for _, f := range pages[pn] {
+			var field huh.Field
+
+			switch f.Type {
+			case "note":
+				field = huh.NewNote().Title(f.Title).Description(f.Description)
+
+			case "confirm":
+				val := new(bool)
+				boolValues[f.Key] = val
+				field = huh.NewConfirm().
+					Title(f.Title).
+					Description(f.Description).
+					Value(val)
+
+			case "select":
+				val := new(string)
+				*val = f.Default
+				fieldValues[f.Key] = val
+				opts := make([]huh.Option[string], len(f.Options))
+				for i, o := range f.Options {
+					opts[i] = huh.NewOption(o, o)
+				}
+				field = huh.NewSelect[string]().
+					Title(f.Title).
+					Description(f.Description).
+					Options(opts...).
+					Value(val)
+
+			case "multiselect":
+				multiVal := new([]string)
+				multiValues[f.Key] = multiVal
+				opts := make([]huh.Option[string], len(f.Options))
+				for i, o := range f.Options {
+					opts[i] = huh.NewOption(o, o)
+				}
+				field = huh.NewMultiSelect[string]().
+					Title(f.Title).
+					Description(f.Description).
+					Options(opts...).
+					Value(multiVal)
+
+			case "password":
+				val := new(string)
+				*val = f.Default
+				fieldValues[f.Key] = val
+				field = huh.NewInput().
+					Title(f.Title).
+					Description(f.Description).
+					EchoMode(huh.EchoModePassword).
+					Validate(buildValidator(f)).
+					Value(val)
+
+			default: // "input" and anything unrecognised falls back to a text input
+				val := new(string)
+				*val = f.Default
+				fieldValues[f.Key] = val
+				field = huh.NewInput().
+					Title(f.Title).
+					Description(f.Description).
+					Validate(buildValidator(f)).
+					Value(val)
+			}
+
+			if f.Key != "" {
+				order = append(order, f.Key)
+			}
+
+			if f.When == "" {
+				huhFields = append(huhFields, field)
+				continue
+			}
+
+			// Conditional fields can't join the shared page group (huh
+			// only hides whole groups), so flush whatever's pending and
+			// give this field its own group with a live hide check.
+			flush()
+			when := f.When
+			groups = append(groups, huh.NewGroup(field).WithHideFunc(func() bool {
+				return !evalWhen(when, snapshot())
+			}))
+		}
+
+		flush()
+	}
+
+	form := huh.NewForm(groups...).WithTheme(huhTheme)
+	if err := form.Run(); err != nil {
+		return nil, nil, fmt.Errorf("running form: %w", err)
+	}
+
+	for key, val := range fieldValues {
+		if val != nil {
+			answers[key] = *val
+		}
+	}
+	for key, val := range boolValues {
+		if *val {
+			answers[key] = "true"
+		} else {
+			answers[key] = "false"
+		}
+	}
+	for key, val := range multiValues {
+		answers[key] = *val
+	}
+
+	return answers, order, nil
+}
+
+// formEnvValue renders an answer for the "env" output format, which can only
+// carry flat strings: multiselect answers are comma-joined there, same as
+// before this function existed.
+func formEnvValue(v interface{}) string {
+	if list, ok := v.([]string); ok {
+		return strings.Join(list, ",")
+	}
+	return fmt.Sprintf("%v", v)
+}
+
+func writeFormResult(resultFile, format string, answers map[string]interface{}, order []string) error {
+	var out []byte
+	var err error
+
+	switch format {
+	case "env":
+		var lines []string
+		for _, key := range order {
+			lines = append(lines, fmt.Sprintf("%s=%s", key, formEnvValue(answers[key])))
+		}
+		out = []byte(strings.Join(lines, "\n") + "\n")
+	default:
+		out, err = json.MarshalIndent(answers, "", "  ")
+		if err != nil {
+			return err
+		}
+		out = append(out, '\n')
+	}
+
+	if resultFile != "" {
+		return os.WriteFile(resultFile, out, 0644)
+	}
+	fmt.Print(string(out))
+	return nil
+}
@@ -0,0 +1,40 @@
+package main
+
+import "testing"
+
+func TestEvalWhen(t *testing.T) {
+	answers := map[string]string{
+		"role":      "admin",
+		"env":       "prod",
+		"confirm":   "true",
+		"has comma": "a,b",
+	}
+
+	tests := []struct {
+		name string
+		expr string
+		want bool
+	}{
+		{name: "empty expression is always true", expr: "", want: true},
+		{name: "equality true", expr: "role==admin", want: true},
+		{name: "equality false", expr: "role==viewer", want: false},
+		{name: "inequality true", expr: "role!=viewer", want: true},
+		{name: "inequality false", expr: "role!=admin", want: false},
+		{name: "quoted value", expr: `role=="admin"`, want: true},
+		{name: "whitespace around operands", expr: " role == admin ", want: true},
+		{name: "missing key compares as empty", expr: "missing==", want: true},
+		{name: "and both true", expr: "role==admin && env==prod", want: true},
+		{name: "and one false", expr: "role==admin && env==staging", want: false},
+		{name: "or one true", expr: "role==viewer || env==prod", want: true},
+		{name: "or both false", expr: "role==viewer || env==staging", want: false},
+		{name: "value containing comma", expr: "has comma==a,b", want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := evalWhen(tt.expr, answers); got != tt.want {
+				t.Fatalf("evalWhen(%q, answers) = %v, want %v", tt.expr, got, tt.want)
+			}
+		})
+	}
+}
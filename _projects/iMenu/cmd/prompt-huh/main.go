@@ -3,6 +3,7 @@ package main
 import (
 	"fmt"
 	"os"
+	"strconv"
 	"strings"
 
 	"github.com/charmbracelet/huh"
@@ -11,23 +12,66 @@ import (
 func main() {
 	if len(os.Args) < 2 {
 		fmt.Fprintf(os.Stderr, "Usage: %s <type> [options] [--result-file FILE]\n", os.Args[0])
-		fmt.Fprintf(os.Stderr, "Types: input, select, confirm, multiselect\n")
+		fmt.Fprintf(os.Stderr, "Types: input, select, confirm, multiselect, fuzzy-select, fuzzy-multiselect, password, multiline, editor, form, dump-theme\n")
 		os.Exit(1)
 	}
 
-	// Parse result file option
+	// Parse result file / format / validate / theme options
 	resultFile := ""
+	format := "json"
+	validateSpec := ""
+	minLength := 0
+	themeName := ""
+	themeFile := ""
 	args := os.Args[1:]
-	for i, arg := range args {
-		if arg == "--result-file" && i+1 < len(args) {
+	for i := 0; i < len(args); i++ {
+		switch {
+		case args[i] == "--result-file" && i+1 < len(args):
 			resultFile = args[i+1]
 			args = append(args[:i], args[i+2:]...)
-			break
+			i--
+		case strings.HasPrefix(args[i], "--format="):
+			format = strings.TrimPrefix(args[i], "--format=")
+			args = append(args[:i], args[i+1:]...)
+			i--
+		case args[i] == "--validate" && i+1 < len(args):
+			validateSpec = args[i+1]
+			args = append(args[:i], args[i+2:]...)
+			i--
+		case args[i] == "--min-length" && i+1 < len(args):
+			minLength, _ = strconv.Atoi(args[i+1])
+			args = append(args[:i], args[i+2:]...)
+			i--
+		case args[i] == "--theme" && i+1 < len(args):
+			themeName = args[i+1]
+			args = append(args[:i], args[i+2:]...)
+			i--
+		case args[i] == "--theme-file" && i+1 < len(args):
+			themeFile = args[i+1]
+			args = append(args[:i], args[i+2:]...)
+			i--
 		}
 	}
-	
+	validate := parseValidateSpec(validateSpec)
+	theme, err := loadTheme(themeName, themeFile)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	huhTheme := toHuhTheme(theme)
+
 	promptType := args[0]
 
+	if promptType == "dump-theme" {
+		dumped, err := dumpTheme(theme)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Print(dumped)
+		return
+	}
+
 	// Helper to write result
 	writeResult := func(data string) {
 		if resultFile != "" {
@@ -65,9 +109,10 @@ func main() {
 					Title(message).
 					Description("Type your answer and press Enter").
 					Value(&result).
+					Validate(validate).
 					Placeholder(defaultValue),
 			),
-		)
+		).WithTheme(huhTheme)
 
 		if err := form.Run(); err != nil {
 			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
@@ -79,7 +124,7 @@ func main() {
 		}
 		writeResult(result)
 
-	case "select":
+	case "select", "fuzzy-select":
 		if len(args) < 2 {
 			fmt.Fprintf(os.Stderr, "Usage: %s select <message> <option1> [option2] ...\n", os.Args[0])
 			os.Exit(1)
@@ -100,15 +145,21 @@ func main() {
 			huhOptions[i] = huh.NewOption(opt, opt)
 		}
 
+		description := "Use arrow keys to navigate, Enter to select"
+		if promptType == "fuzzy-select" {
+			description = "Type to fuzzy-filter, arrow keys to navigate, Enter to select"
+		}
+
 		form := huh.NewForm(
 			huh.NewGroup(
 				huh.NewSelect[string]().
 					Title(message).
-					Description("Use arrow keys to navigate, Enter to select").
+					Description(description).
 					Options(huhOptions...).
+					Filtering(promptType == "fuzzy-select").
 					Value(&result),
 			),
-		)
+		).WithTheme(huhTheme)
 
 		if err := form.Run(); err != nil {
 			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
@@ -131,7 +182,7 @@ func main() {
 					Description("Use arrow keys to switch, Enter to confirm").
 					Value(&result),
 			),
-		)
+		).WithTheme(huhTheme)
 
 		if err := form.Run(); err != nil {
 			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
@@ -146,7 +197,7 @@ func main() {
 			os.Exit(1)
 		}
 
-	case "multiselect":
+	case "multiselect", "fuzzy-multiselect":
 		if len(args) < 2 {
 			fmt.Fprintf(os.Stderr, "Usage: %s multiselect <message> <option1> [option2] ...\n", os.Args[0])
 			os.Exit(1)
@@ -167,15 +218,21 @@ func main() {
 			huhOptions[i] = huh.NewOption(opt, opt)
 		}
 
+		description := "Use arrow keys to navigate, Space to toggle, Enter when done"
+		if promptType == "fuzzy-multiselect" {
+			description = "Type to fuzzy-filter, Space to toggle, Enter when done"
+		}
+
 		form := huh.NewForm(
 			huh.NewGroup(
 				huh.NewMultiSelect[string]().
 					Title(message).
-					Description("Use arrow keys to navigate, Space to toggle, Enter when done").
+					Description(description).
 					Options(huhOptions...).
+					Filtering(promptType == "fuzzy-multiselect").
 					Value(&result),
 			),
-		)
+		).WithTheme(huhTheme)
 
 		if err := form.Run(); err != nil {
 			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
@@ -185,6 +242,100 @@ func main() {
 		// Output selected options, one per line
 		writeResultLines(result)
 
+	case "password":
+		var result string
+		message := "Enter password:"
+		if len(args) > 1 {
+			message = args[1]
+		}
+
+		form := huh.NewForm(
+			huh.NewGroup(
+				huh.NewInput().
+					Title(message).
+					Description("Type your answer and press Enter").
+					EchoMode(huh.EchoModePassword).
+					Validate(func(s string) error {
+						if minLength > 0 && len(s) < minLength {
+							return fmt.Errorf("must be at least %d characters", minLength)
+						}
+						return validate(s)
+					}).
+					Value(&result),
+			),
+		).WithTheme(huhTheme)
+
+		if err := form.Run(); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		writeResult(result)
+
+	case "multiline":
+		var result string
+		message := "Enter text:"
+		if len(args) > 1 {
+			message = args[1]
+		}
+
+		form := huh.NewForm(
+			huh.NewGroup(
+				huh.NewText().
+					Title(message).
+					Description("Ctrl+D to submit").
+					Validate(validate).
+					Value(&result),
+			),
+		).WithTheme(huhTheme)
+
+		if err := form.Run(); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		writeResult(result)
+
+	case "editor":
+		message := "Enter value:"
+		defaultValue := ""
+		if len(args) > 1 {
+			message = args[1]
+		}
+		if len(args) > 2 {
+			defaultValue = args[2]
+		}
+
+		fmt.Fprintf(os.Stderr, "%s (opening $EDITOR)\n", message)
+		result, err := runEditor(defaultValue)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		if err := validate(result); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		writeResult(result)
+
+	case "form":
+		if len(args) < 2 {
+			fmt.Fprintf(os.Stderr, "Usage: %s form <spec-file> [--format=json|env] [--result-file FILE]\n", os.Args[0])
+			os.Exit(1)
+		}
+
+		answers, order, err := runForm(args[1], huhTheme)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		if err := writeFormResult(resultFile, format, answers, order); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
 	default:
 		fmt.Fprintf(os.Stderr, "Unknown prompt type: %s\n", promptType)
 		os.Exit(1)
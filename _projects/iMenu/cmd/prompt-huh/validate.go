@@ -0,0 +1,78 @@
+package main
+
+import (
+	"fmt"
+	"net/mail"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// parseValidateSpec turns a --validate flag value (one of "email", "url",
+// "int", "float", "nonempty", or "regex:PATTERN") into a huh validator func.
+// An empty spec means no validation. An unrecognised spec is treated as
+// nonempty, since that's the closest safe default.
+func parseValidateSpec(spec string) func(string) error {
+	if spec == "" {
+		return func(string) error { return nil }
+	}
+
+	if rest, ok := strings.CutPrefix(spec, "regex:"); ok {
+		return func(s string) error {
+			ok, err := regexpMatch(rest, s)
+			if err != nil {
+				return err
+			}
+			if !ok {
+				return fmt.Errorf("must match pattern %s", rest)
+			}
+			return nil
+		}
+	}
+
+	switch spec {
+	case "email":
+		return func(s string) error {
+			if _, err := mail.ParseAddress(s); err != nil {
+				return fmt.Errorf("must be a valid email address")
+			}
+			return nil
+		}
+	case "url":
+		return func(s string) error {
+			u, err := url.ParseRequestURI(s)
+			if err != nil || u.Scheme == "" || u.Host == "" {
+				return fmt.Errorf("must be a valid URL")
+			}
+			return nil
+		}
+	case "int":
+		return func(s string) error {
+			if _, err := strconv.Atoi(s); err != nil {
+				return fmt.Errorf("must be an integer")
+			}
+			return nil
+		}
+	case "float":
+		return func(s string) error {
+			if _, err := strconv.ParseFloat(s, 64); err != nil {
+				return fmt.Errorf("must be a number")
+			}
+			return nil
+		}
+	case "nonempty":
+		return func(s string) error {
+			if strings.TrimSpace(s) == "" {
+				return fmt.Errorf("must not be empty")
+			}
+			return nil
+		}
+	default:
+		return func(s string) error {
+			if strings.TrimSpace(s) == "" {
+				return fmt.Errorf("must not be empty")
+			}
+			return nil
+		}
+	}
+}